@@ -1,21 +1,248 @@
 package main
 
 import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"math"
+	"math/rand"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"os"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"github.com/nyaruka/phonenumbers"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/rs/zerolog"
+	"github.com/spf13/cobra"
+	"go.etcd.io/bbolt"
+	"golang.org/x/sync/errgroup"
+	"golang.org/x/time/rate"
+	_ "modernc.org/sqlite"
 )
 
 type WhatsAppRealtimeChecker struct {
 	apiKey     string
 	baseURL    string
 	httpClient *http.Client
+	validator  NumberValidator
+
+	cache       Cache
+	cacheTTL    time.Duration
+	cacheHits   int64
+	cacheMisses int64
+
+	metrics *MetricsCollector
+	logger  Logger
+}
+
+// CheckerOption customizes a WhatsAppRealtimeChecker created by
+// NewWhatsAppRealtimeChecker.
+type CheckerOption func(*WhatsAppRealtimeChecker)
+
+// WithValidator overrides the default libphonenumber-backed NumberValidator.
+func WithValidator(v NumberValidator) CheckerOption {
+	return func(w *WhatsAppRealtimeChecker) {
+		w.validator = v
+	}
+}
+
+// WithCache enables result caching in CheckNumber, skipping the HTTP call
+// for repeat lookups that are still within the cache's TTL.
+func WithCache(c Cache) CheckerOption {
+	return func(w *WhatsAppRealtimeChecker) {
+		w.cache = c
+	}
+}
+
+// WithCacheTTL sets how long a cached result stays fresh. Defaults to 24h.
+func WithCacheTTL(d time.Duration) CheckerOption {
+	return func(w *WhatsAppRealtimeChecker) {
+		w.cacheTTL = d
+	}
+}
+
+// WithMetrics wires a MetricsCollector into CheckNumber, the worker pool,
+// and cache lookups.
+func WithMetrics(m *MetricsCollector) CheckerOption {
+	return func(w *WhatsAppRealtimeChecker) {
+		w.metrics = m
+	}
+}
+
+// WithLogger wires a structured Logger into CheckNumber, the worker pool,
+// and cache lookups.
+func WithLogger(l Logger) CheckerOption {
+	return func(w *WhatsAppRealtimeChecker) {
+		w.logger = l
+	}
+}
+
+// Logger is a minimal structured-logging interface satisfied directly by
+// *zap.SugaredLogger, and by zerolog via NewZerologLogger.
+type Logger interface {
+	Infow(msg string, keysAndValues ...interface{})
+	Errorw(msg string, keysAndValues ...interface{})
+}
+
+// zerologLogger adapts a zerolog.Logger to the Logger interface.
+type zerologLogger struct {
+	log zerolog.Logger
+}
+
+// NewZerologLogger adapts log to the Logger interface expected by
+// WithLogger.
+func NewZerologLogger(log zerolog.Logger) Logger {
+	return &zerologLogger{log: log}
+}
+
+func (z *zerologLogger) Infow(msg string, keysAndValues ...interface{}) {
+	z.event(z.log.Info(), msg, keysAndValues)
+}
+
+func (z *zerologLogger) Errorw(msg string, keysAndValues ...interface{}) {
+	z.event(z.log.Error(), msg, keysAndValues)
+}
+
+func (z *zerologLogger) event(e *zerolog.Event, msg string, keysAndValues []interface{}) {
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		key, _ := keysAndValues[i].(string)
+		e = e.Interface(key, keysAndValues[i+1])
+	}
+	e.Msg(msg)
+}
+
+// MetricsCollector registers the Prometheus series CheckNumber, the worker
+// pool, and the cache report into. Mount MetricsHandler on your own
+// http.Server to expose it.
+type MetricsCollector struct {
+	registry      *prometheus.Registry
+	checksTotal   *prometheus.CounterVec
+	checkDuration *prometheus.HistogramVec
+	checkErrors   *prometheus.CounterVec
+	rateLimitHits prometheus.Counter
+}
+
+// NewMetricsCollector creates and registers the whatsapp_* Prometheus
+// series.
+func NewMetricsCollector() *MetricsCollector {
+	m := &MetricsCollector{
+		registry: prometheus.NewRegistry(),
+		checksTotal: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "whatsapp_checks_total",
+			Help: "Total number of WhatsApp checks, by country and result.",
+		}, []string{"country", "result"}),
+		checkDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name: "whatsapp_check_duration_seconds",
+			Help: "Latency of WhatsApp checks, by country.",
+		}, []string{"country"}),
+		checkErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "whatsapp_check_errors_total",
+			Help: "Total number of WhatsApp check errors, by kind.",
+		}, []string{"kind"}),
+		rateLimitHits: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "whatsapp_ratelimit_hits_total",
+			Help: "Total number of HTTP 429 responses received.",
+		}),
+	}
+
+	m.registry.MustRegister(m.checksTotal, m.checkDuration, m.checkErrors, m.rateLimitHits)
+
+	return m
+}
+
+// MetricsHandler returns an http.Handler serving the collector's series in
+// the Prometheus text exposition format, for mounting at e.g. "/metrics".
+// It returns a 404 handler if the checker was created without WithMetrics.
+func (w *WhatsAppRealtimeChecker) MetricsHandler() http.Handler {
+	if w.metrics == nil {
+		return http.NotFoundHandler()
+	}
+	return promhttp.HandlerFor(w.metrics.registry, promhttp.HandlerOpts{})
+}
+
+// NumberValidator validates and normalizes a phone number for a given
+// region, returning the E.164 form used for the API call.
+type NumberValidator interface {
+	Validate(number, region string) (e164 string, err error)
+}
+
+// NumberParseErrorKind distinguishes the ways a number can fail validation.
+type NumberParseErrorKind int
+
+const (
+	ErrInvalidCountry NumberParseErrorKind = iota
+	ErrNotANumber
+	ErrNotMobile
+	ErrWrongRegion
+)
+
+// NumberParseError reports why NumberValidator.Validate rejected a number.
+type NumberParseError struct {
+	Kind   NumberParseErrorKind
+	Number string
+	Region string
+	Err    error
+}
+
+func (e *NumberParseError) Error() string {
+	return fmt.Sprintf("invalid number %q for region %q: %v", e.Number, e.Region, e.Err)
+}
+
+func (e *NumberParseError) Unwrap() error {
+	return e.Err
+}
+
+// defaultValidator validates numbers against github.com/nyaruka/phonenumbers
+// (a Go port of Google's libphonenumber), covering every ISO-3166 region
+// rather than a fixed allow-list.
+type defaultValidator struct{}
+
+// NewDefaultValidator returns the libphonenumber-backed NumberValidator used
+// by NewWhatsAppRealtimeChecker unless overridden with WithValidator.
+func NewDefaultValidator() NumberValidator {
+	return defaultValidator{}
+}
+
+func (defaultValidator) Validate(number, region string) (string, error) {
+	region = strings.ToUpper(region)
+
+	parsed, err := phonenumbers.Parse(number, region)
+	if err != nil {
+		kind := ErrNotANumber
+		if err == phonenumbers.ErrInvalidCountryCode {
+			kind = ErrInvalidCountry
+		}
+		return "", &NumberParseError{Kind: kind, Number: number, Region: region, Err: err}
+	}
+
+	if !phonenumbers.IsValidNumber(parsed) {
+		return "", &NumberParseError{Kind: ErrWrongRegion, Number: number, Region: region, Err: fmt.Errorf("not a valid number for region %s", region)}
+	}
+
+	switch phonenumbers.GetNumberType(parsed) {
+	case phonenumbers.MOBILE, phonenumbers.FIXED_LINE_OR_MOBILE:
+	default:
+		return "", &NumberParseError{Kind: ErrNotMobile, Number: number, Region: region, Err: fmt.Errorf("not a mobile number")}
+	}
+
+	return phonenumbers.Format(parsed, phonenumbers.E164), nil
 }
 
 type WhatsAppResponse struct {
@@ -49,30 +276,148 @@ type CheckStatistics struct {
 	Failed      int `json:"failed"`
 	WhatsAppYes int `json:"whatsappYes"`
 	WhatsAppNo  int `json:"whatsappNo"`
+	CacheHits   int `json:"cacheHits"`
+	CacheMisses int `json:"cacheMisses"`
 }
 
-func NewWhatsAppRealtimeChecker(apiKey string) *WhatsAppRealtimeChecker {
-	return &WhatsAppRealtimeChecker{
+// CountryBreakdown is a CheckStatistics scoped to a single country, as
+// produced by GetStatisticsByCountry and the CLI's "stats" subcommand.
+type CountryBreakdown struct {
+	Country string `json:"country"`
+	CheckStatistics
+}
+
+func NewWhatsAppRealtimeChecker(apiKey string, opts ...CheckerOption) *WhatsAppRealtimeChecker {
+	w := &WhatsAppRealtimeChecker{
 		apiKey:  apiKey,
 		baseURL: "https://api.checknumber.ai/v1/realtime/whatsapp",
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		validator: NewDefaultValidator(),
+		cacheTTL:  24 * time.Hour,
+	}
+
+	for _, opt := range opts {
+		opt(w)
 	}
+
+	return w
 }
 
 func (w *WhatsAppRealtimeChecker) CheckNumber(number, country, callback string) (*WhatsAppResponse, error) {
+	result, _, err := w.checkNumberStatus(number, country, callback)
+	return result, err
+}
+
+// checkNumberStatus is like CheckNumber but also returns the HTTP status
+// code so callers (e.g. CheckMultipleNumbersConcurrent) can decide whether a
+// failure is retryable.
+func (w *WhatsAppRealtimeChecker) checkNumberStatus(number, country, callback string) (*WhatsAppResponse, int, error) {
+	start := time.Now()
+	result, statusCode, err := w.doCheckNumberStatus(number, country, callback)
+	w.observe(number, country, start, result, statusCode, err)
+	return result, statusCode, err
+}
+
+// observe records metrics and structured logs for a completed check. It is
+// a no-op for whichever of metrics/logger was never configured via
+// WithMetrics/WithLogger.
+func (w *WhatsAppRealtimeChecker) observe(number, country string, start time.Time, result *WhatsAppResponse, statusCode int, err error) {
+	latency := time.Since(start)
+	country = strings.ToUpper(country)
+
+	if w.metrics != nil {
+		outcome := "success"
+		if err != nil {
+			outcome = "error"
+			w.metrics.checkErrors.WithLabelValues(errorKind(err)).Inc()
+		}
+		w.metrics.checksTotal.WithLabelValues(country, outcome).Inc()
+		if statusCode != 0 {
+			w.metrics.checkDuration.WithLabelValues(country).Observe(latency.Seconds())
+		}
+		if statusCode == http.StatusTooManyRequests {
+			w.metrics.rateLimitHits.Inc()
+		}
+	}
+
+	if w.logger != nil {
+		transactionID := ""
+		if result != nil {
+			transactionID = result.TransactionID
+		}
+
+		fields := []interface{}{
+			"transaction_id", transactionID,
+			"number_masked", maskNumber(number),
+			"country", country,
+			"latency_ms", latency.Milliseconds(),
+			"http_status", statusCode,
+		}
+
+		if err != nil {
+			w.logger.Errorw("whatsapp check failed", append(fields, "error", err.Error())...)
+		} else {
+			w.logger.Infow("whatsapp check completed", fields...)
+		}
+	}
+}
+
+// maskNumber redacts all but the first two and last two digits of number,
+// so logs don't retain full phone numbers.
+func maskNumber(number string) string {
+	if len(number) <= 4 {
+		return strings.Repeat("*", len(number))
+	}
+	return number[:2] + strings.Repeat("*", len(number)-4) + number[len(number)-2:]
+}
+
+// errorKind classifies err into a low-cardinality label for
+// whatsapp_check_errors_total.
+func errorKind(err error) string {
+	var parseErr *NumberParseError
+	switch {
+	case errors.As(err, &parseErr):
+		return "invalid_number"
+	case strings.Contains(err.Error(), "HTTP error"):
+		return "http"
+	case strings.Contains(err.Error(), "failed to decode response"):
+		return "decode"
+	case strings.Contains(err.Error(), "failed to create request"):
+		return "request"
+	default:
+		return "network"
+	}
+}
+
+// doCheckNumberStatus performs the actual cache lookup and HTTP call;
+// checkNumberStatus wraps it with metrics/logging instrumentation.
+func (w *WhatsAppRealtimeChecker) doCheckNumberStatus(number, country, callback string) (*WhatsAppResponse, int, error) {
+	normalized, err := w.validator.Validate(number, country)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if w.cache != nil {
+		if cached, ok := w.cache.Get(normalized); ok {
+			atomic.AddInt64(&w.cacheHits, 1)
+			return cached, http.StatusOK, nil
+		}
+		atomic.AddInt64(&w.cacheMisses, 1)
+	}
+
 	data := url.Values{}
-	data.Set("number", number)
+	data.Set("number", normalized)
 	data.Set("country", strings.ToUpper(country))
-	
+
 	if callback != "" {
 		data.Set("callback", callback)
 	}
 
 	req, err := http.NewRequest("POST", w.baseURL, strings.NewReader(data.Encode()))
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %v", err)
+		return nil, 0, fmt.Errorf("failed to create request: %v", err)
 	}
 
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
@@ -80,25 +425,29 @@ func (w *WhatsAppRealtimeChecker) CheckNumber(number, country, callback string)
 
 	resp, err := w.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %v", err)
+		return nil, 0, fmt.Errorf("request failed: %v", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %v", err)
+		return nil, resp.StatusCode, fmt.Errorf("failed to read response: %v", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
+		return nil, resp.StatusCode, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
 	}
 
 	var result WhatsAppResponse
 	if err := json.Unmarshal(body, &result); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %v", err)
+		return nil, resp.StatusCode, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	if w.cache != nil {
+		w.cache.Put(normalized, &result, w.cacheTTL)
 	}
 
-	return &result, nil
+	return &result, resp.StatusCode, nil
 }
 
 func (w *WhatsAppRealtimeChecker) CheckMultipleNumbers(numbersData []NumberData, delayMs int) []CheckResult {
@@ -133,6 +482,568 @@ func (w *WhatsAppRealtimeChecker) CheckMultipleNumbers(numbersData []NumberData,
 	return results
 }
 
+// Cache stores WhatsAppResponse results keyed by normalized number so
+// CheckNumber can skip the HTTP call for repeat lookups.
+type Cache interface {
+	Get(number string) (*WhatsAppResponse, bool)
+	Put(number string, resp *WhatsAppResponse, ttl time.Duration)
+}
+
+type cacheEntry struct {
+	Response  *WhatsAppResponse
+	ExpiresAt time.Time
+}
+
+// memoryCache is an in-process LRU Cache with no external dependencies.
+type memoryCache struct {
+	mu       sync.Mutex
+	capacity int
+	ll       *list.List
+	items    map[string]*list.Element
+}
+
+type memoryCacheElement struct {
+	key   string
+	entry cacheEntry
+}
+
+// NewMemoryCache returns an in-memory LRU Cache holding at most capacity
+// entries.
+func NewMemoryCache(capacity int) Cache {
+	return &memoryCache{
+		capacity: capacity,
+		ll:       list.New(),
+		items:    make(map[string]*list.Element),
+	}
+}
+
+func (c *memoryCache) Get(number string) (*WhatsAppResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.items[number]
+	if !ok {
+		return nil, false
+	}
+
+	el := elem.Value.(*memoryCacheElement)
+	if time.Now().After(el.entry.ExpiresAt) {
+		c.ll.Remove(elem)
+		delete(c.items, number)
+		return nil, false
+	}
+
+	c.ll.MoveToFront(elem)
+	return el.entry.Response, true
+}
+
+func (c *memoryCache) Put(number string, resp *WhatsAppResponse, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry := cacheEntry{Response: resp, ExpiresAt: time.Now().Add(ttl)}
+
+	if elem, ok := c.items[number]; ok {
+		elem.Value.(*memoryCacheElement).entry = entry
+		c.ll.MoveToFront(elem)
+		return
+	}
+
+	elem := c.ll.PushFront(&memoryCacheElement{key: number, entry: entry})
+	c.items[number] = elem
+
+	if c.ll.Len() > c.capacity {
+		oldest := c.ll.Back()
+		if oldest != nil {
+			c.ll.Remove(oldest)
+			delete(c.items, oldest.Value.(*memoryCacheElement).key)
+		}
+	}
+}
+
+var cacheBucketName = []byte("whatsapp_results")
+
+// boltCache is a Cache backed by a BoltDB file, for persisting results
+// across process restarts.
+type boltCache struct {
+	db *bbolt.DB
+}
+
+// NewBoltCache opens (creating if necessary) a BoltDB-backed Cache at path.
+func NewBoltCache(path string) (Cache, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open bolt db: %v", err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		_, err := tx.CreateBucketIfNotExists(cacheBucketName)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bucket: %v", err)
+	}
+
+	return &boltCache{db: db}, nil
+}
+
+func (c *boltCache) Get(number string) (*WhatsAppResponse, bool) {
+	var entry cacheEntry
+
+	err := c.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(cacheBucketName).Get([]byte(number))
+		if raw == nil {
+			return errCacheMiss
+		}
+		return json.Unmarshal(raw, &entry)
+	})
+	if err != nil || time.Now().After(entry.ExpiresAt) {
+		return nil, false
+	}
+
+	return entry.Response, true
+}
+
+func (c *boltCache) Put(number string, resp *WhatsAppResponse, ttl time.Duration) {
+	entry := cacheEntry{Response: resp, ExpiresAt: time.Now().Add(ttl)}
+	raw, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	_ = c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(cacheBucketName).Put([]byte(number), raw)
+	})
+}
+
+var errCacheMiss = fmt.Errorf("cache miss")
+
+// sqliteCache is a Cache backed by a SQLite database, for persisting
+// results across process restarts when a single file isn't convenient.
+type sqliteCache struct {
+	db *sql.DB
+}
+
+// NewSQLiteCache opens (creating if necessary) a SQLite-backed Cache at
+// path.
+func NewSQLiteCache(path string) (Cache, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite db: %v", err)
+	}
+
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS cache (
+		number TEXT PRIMARY KEY,
+		response TEXT NOT NULL,
+		expires_at INTEGER NOT NULL
+	)`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create table: %v", err)
+	}
+
+	return &sqliteCache{db: db}, nil
+}
+
+func (c *sqliteCache) Get(number string) (*WhatsAppResponse, bool) {
+	var response string
+	var expiresAt int64
+
+	row := c.db.QueryRow(`SELECT response, expires_at FROM cache WHERE number = ?`, number)
+	if err := row.Scan(&response, &expiresAt); err != nil {
+		return nil, false
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return nil, false
+	}
+
+	var result WhatsAppResponse
+	if err := json.Unmarshal([]byte(response), &result); err != nil {
+		return nil, false
+	}
+
+	return &result, true
+}
+
+func (c *sqliteCache) Put(number string, resp *WhatsAppResponse, ttl time.Duration) {
+	raw, err := json.Marshal(resp)
+	if err != nil {
+		return
+	}
+
+	_, _ = c.db.Exec(
+		`INSERT INTO cache (number, response, expires_at) VALUES (?, ?, ?)
+		 ON CONFLICT(number) DO UPDATE SET response = excluded.response, expires_at = excluded.expires_at`,
+		number, string(raw), time.Now().Add(ttl).Unix(),
+	)
+}
+
+// ConcurrentOptions configures CheckMultipleNumbersConcurrent.
+type ConcurrentOptions struct {
+	// Workers is the number of numbers checked in parallel. Defaults to 10.
+	Workers int
+	// RPS caps the aggregate request rate across all workers. Defaults to 5.
+	RPS float64
+}
+
+const (
+	backoffBase   = 500 * time.Millisecond
+	backoffCap    = 30 * time.Second
+	backoffFactor = 2.0
+	backoffJitter = 0.2
+
+	circuitFailureThreshold = 5
+	circuitCooldown         = 30 * time.Second
+)
+
+// circuitBreaker pauses all workers for a cool-down window after too many
+// consecutive request failures, so a struggling upstream isn't hammered.
+type circuitBreaker struct {
+	mu          sync.Mutex
+	failures    int
+	openedUntil time.Time
+}
+
+func (b *circuitBreaker) recordResult(ok bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if ok {
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.failures >= circuitFailureThreshold {
+		b.openedUntil = time.Now().Add(circuitCooldown)
+		b.failures = 0
+	}
+}
+
+// waitIfOpen blocks until the cool-down window (if any) elapses, or ctx is
+// cancelled.
+func (b *circuitBreaker) waitIfOpen(ctx context.Context) error {
+	b.mu.Lock()
+	until := b.openedUntil
+	b.mu.Unlock()
+
+	if wait := time.Until(until); wait > 0 {
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func isRetryableStatus(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// backoffDelay returns the exponential backoff delay with jitter for the
+// given (zero-based) retry attempt.
+func backoffDelay(attempt int) time.Duration {
+	delay := float64(backoffBase) * math.Pow(backoffFactor, float64(attempt))
+	if delay > float64(backoffCap) {
+		delay = float64(backoffCap)
+	}
+
+	jitter := delay * backoffJitter
+	delay += (rand.Float64()*2 - 1) * jitter
+
+	return time.Duration(delay)
+}
+
+// CheckMultipleNumbersConcurrent checks numbersData using a bounded worker
+// pool with adaptive rate limiting, replacing the sequential loop in
+// CheckMultipleNumbers. Results stream over the returned channel as they
+// complete so callers can consume them incrementally; the channel is closed
+// once every number has been checked or ctx is cancelled.
+func (w *WhatsAppRealtimeChecker) CheckMultipleNumbersConcurrent(ctx context.Context, numbersData []NumberData, opts ConcurrentOptions) <-chan CheckResult {
+	if opts.Workers <= 0 {
+		opts.Workers = 10
+	}
+	if opts.RPS <= 0 {
+		opts.RPS = 5
+	}
+
+	limiter := rate.NewLimiter(rate.Limit(opts.RPS), opts.Workers)
+	breaker := &circuitBreaker{}
+	results := make(chan CheckResult)
+
+	go func() {
+		defer close(results)
+
+		jobs := make(chan NumberData)
+		go func() {
+			defer close(jobs)
+			for _, data := range numbersData {
+				select {
+				case jobs <- data:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+
+		g, gctx := errgroup.WithContext(ctx)
+		for i := 0; i < opts.Workers; i++ {
+			g.Go(func() error {
+				for data := range jobs {
+					result := w.checkWithRetry(gctx, data, limiter, breaker)
+					select {
+					case results <- result:
+					case <-gctx.Done():
+						return gctx.Err()
+					}
+				}
+				return nil
+			})
+		}
+		_ = g.Wait()
+	}()
+
+	return results
+}
+
+// checkWithRetry performs a single number check, retrying on HTTP 429/5xx
+// with exponential backoff and respecting an open circuit breaker, until it
+// succeeds, exhausts retries, or ctx is cancelled.
+func (w *WhatsAppRealtimeChecker) checkWithRetry(ctx context.Context, data NumberData, limiter *rate.Limiter, breaker *circuitBreaker) CheckResult {
+	checkResult := CheckResult{Input: data}
+
+	for attempt := 0; ; attempt++ {
+		if err := breaker.waitIfOpen(ctx); err != nil {
+			checkResult.ErrorMessage = err.Error()
+			return checkResult
+		}
+		if err := limiter.Wait(ctx); err != nil {
+			checkResult.ErrorMessage = err.Error()
+			return checkResult
+		}
+
+		result, statusCode, err := w.checkNumberStatus(data.Number, data.Country, data.Callback)
+
+		var parseErr *NumberParseError
+		if err == nil || !errors.As(err, &parseErr) {
+			// Client-side validation failures say nothing about upstream
+			// health, so they shouldn't count toward tripping the breaker.
+			breaker.recordResult(err == nil)
+		}
+
+		if err == nil {
+			checkResult.Result = result
+			checkResult.Success = true
+			return checkResult
+		}
+
+		if !isRetryableStatus(statusCode) || attempt >= 5 {
+			checkResult.ErrorMessage = err.Error()
+			return checkResult
+		}
+
+		select {
+		case <-time.After(backoffDelay(attempt)):
+		case <-ctx.Done():
+			checkResult.ErrorMessage = ctx.Err().Error()
+			return checkResult
+		}
+	}
+}
+
+// BatchOptions configures an asynchronous batch submission.
+type BatchOptions struct {
+	// Callback is a public URL that receives a per-number webhook POST as
+	// each result becomes available. If empty, results must be retrieved
+	// with GetBatchStatus or WaitForBatch.
+	Callback string
+	// CallbackSecret is used to HMAC-sign callback payloads so the
+	// receiving NewCallbackServer can verify authenticity.
+	CallbackSecret string
+}
+
+// BatchJob tracks an in-flight asynchronous batch submitted via SubmitBatch.
+type BatchJob struct {
+	JobID   string `json:"jobId"`
+	Status  string `json:"status"`
+	Total   int    `json:"total"`
+	Done    int    `json:"done"`
+	Results []CheckResult
+}
+
+// SubmitBatch uploads numbersData as a CSV file to the API's async batch
+// endpoint and returns a BatchJob that can be polled with GetBatchStatus or
+// awaited with WaitForBatch, instead of issuing one HTTP call per number.
+func (w *WhatsAppRealtimeChecker) SubmitBatch(numbersData []NumberData, opts BatchOptions) (*BatchJob, error) {
+	var buf bytes.Buffer
+	writer := multipart.NewWriter(&buf)
+
+	csvPart, err := writer.CreateFormFile("file", "numbers.csv")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create form file: %v", err)
+	}
+
+	csvWriter := csv.NewWriter(csvPart)
+	for _, data := range numbersData {
+		callback := data.Callback
+		if callback == "" {
+			callback = opts.Callback
+		}
+		if err := csvWriter.Write([]string{data.Number, strings.ToUpper(data.Country), callback}); err != nil {
+			return nil, fmt.Errorf("failed to write csv row: %v", err)
+		}
+	}
+	csvWriter.Flush()
+	if err := csvWriter.Error(); err != nil {
+		return nil, fmt.Errorf("failed to flush csv: %v", err)
+	}
+
+	if opts.Callback != "" {
+		_ = writer.WriteField("callback", opts.Callback)
+	}
+	if opts.CallbackSecret != "" {
+		_ = writer.WriteField("callbackSecret", opts.CallbackSecret)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close multipart writer: %v", err)
+	}
+
+	req, err := http.NewRequest("POST", w.baseURL+"/batch", &buf)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	req.Header.Set("X-API-Key", w.apiKey)
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusAccepted {
+		return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var job BatchJob
+	if err := json.Unmarshal(body, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+	job.Total = len(numbersData)
+
+	return &job, nil
+}
+
+// GetBatchStatus polls the async batch endpoint for the current state of a
+// job previously returned by SubmitBatch.
+func (w *WhatsAppRealtimeChecker) GetBatchStatus(jobID string) (*BatchJob, error) {
+	req, err := http.NewRequest("GET", w.baseURL+"/batch/"+jobID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %v", err)
+	}
+	req.Header.Set("X-API-Key", w.apiKey)
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %v", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, string(body))
+	}
+
+	var job BatchJob
+	if err := json.Unmarshal(body, &job); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %v", err)
+	}
+
+	return &job, nil
+}
+
+// WaitForBatch polls GetBatchStatus every pollInterval until the job reaches
+// a terminal status ("completed" or "failed") and returns the final job.
+func (w *WhatsAppRealtimeChecker) WaitForBatch(jobID string, pollInterval time.Duration) (*BatchJob, error) {
+	for {
+		job, err := w.GetBatchStatus(jobID)
+		if err != nil {
+			return nil, err
+		}
+
+		switch job.Status {
+		case "completed", "failed":
+			return job, nil
+		}
+
+		time.Sleep(pollInterval)
+	}
+}
+
+// CallbackPayload is the JSON body the API posts to NumberData.Callback as
+// each batch result becomes available.
+type CallbackPayload struct {
+	JobID  string      `json:"jobId"`
+	Result CheckResult `json:"result"`
+}
+
+// NewCallbackServer returns an http.Handler that verifies the
+// X-Signature header (HMAC-SHA256 over the raw request body, keyed by
+// secret) of incoming batch webhook callbacks and invokes onResult for each
+// verified CheckResult. Requests with a missing or invalid signature are
+// rejected with 401.
+func NewCallbackServer(secret string, onResult func(CheckResult)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, "failed to read body", http.StatusBadRequest)
+			return
+		}
+		defer r.Body.Close()
+
+		if !verifyCallbackSignature(secret, body, r.Header.Get("X-Signature")) {
+			http.Error(w, "invalid signature", http.StatusUnauthorized)
+			return
+		}
+
+		var payload CallbackPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "invalid payload", http.StatusBadRequest)
+			return
+		}
+
+		onResult(payload.Result)
+		w.WriteHeader(http.StatusOK)
+	})
+}
+
+func verifyCallbackSignature(secret string, body []byte, signature string) bool {
+	if signature == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
+
 func (w *WhatsAppRealtimeChecker) FormatResult(result *WhatsAppResponse) string {
 	if result.Status == "OK" && result.Message != nil {
 		number := result.Message.Number
@@ -156,11 +1067,24 @@ func (w *WhatsAppRealtimeChecker) FormatResult(result *WhatsAppResponse) string
 	return fmt.Sprintf("Status: %s, Error: %s", result.Status, string(resultJSON))
 }
 
+// GetStatistics aggregates results plus the checker's running cache hit/miss
+// counters, which reflect the whole run rather than just results.
 func (w *WhatsAppRealtimeChecker) GetStatistics(results []CheckResult) CheckStatistics {
+	stats := tallyResults(results)
+
+	stats.CacheHits = int(atomic.LoadInt64(&w.cacheHits))
+	stats.CacheMisses = int(atomic.LoadInt64(&w.cacheMisses))
+
+	return stats
+}
+
+// tallyResults aggregates results into a CheckStatistics without the
+// checker's global cache counters, which aren't scoped to a result subset.
+func tallyResults(results []CheckResult) CheckStatistics {
 	stats := CheckStatistics{
 		Total: len(results),
 	}
-	
+
 	for _, result := range results {
 		if result.Success {
 			stats.Successful++
@@ -176,83 +1100,297 @@ func (w *WhatsAppRealtimeChecker) GetStatistics(results []CheckResult) CheckStat
 			stats.Failed++
 		}
 	}
-	
+
 	return stats
 }
 
-// Helper functions
-func ValidateCountryCode(country string) bool {
-	supportedCountries := []string{"BR", "MX", "NG", "IN", "ID", "US", "CA", "GB", "DE", "FR"}
-	country = strings.ToUpper(country)
-	
-	for _, supported := range supportedCountries {
-		if supported == country {
-			return true
+// GetStatisticsByCountry aggregates results into one CheckStatistics per
+// NumberData.Country, as reported by the CLI's "stats" subcommand.
+func (w *WhatsAppRealtimeChecker) GetStatisticsByCountry(results []CheckResult) []CountryBreakdown {
+	order := []string{}
+	byCountry := map[string][]CheckResult{}
+
+	for _, result := range results {
+		country := strings.ToUpper(result.Input.Country)
+		if _, ok := byCountry[country]; !ok {
+			order = append(order, country)
 		}
+		byCountry[country] = append(byCountry[country], result)
+	}
+
+	breakdown := make([]CountryBreakdown, 0, len(order))
+	for _, country := range order {
+		breakdown = append(breakdown, CountryBreakdown{
+			Country:         country,
+			CheckStatistics: tallyResults(byCountry[country]),
+		})
 	}
-	return false
+
+	return breakdown
 }
 
-func ValidatePhoneNumber(number, country string) bool {
-	// Remove non-digits
-	cleanNumber := ""
-	for _, char := range number {
-		if char >= '0' && char <= '9' {
-			cleanNumber += string(char)
+// readNumbersCSV reads NumberData rows (number,country[,callback]) from a
+// headerless CSV file, as consumed by the CLI's --input/--format csv flags.
+func readNumbersCSV(path string) ([]NumberData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input: %v", err)
+	}
+	defer f.Close()
+
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv: %v", err)
+	}
+
+	numbers := make([]NumberData, 0, len(rows))
+	for i, row := range rows {
+		if len(row) < 2 {
+			return nil, fmt.Errorf("csv row %d: expected at least 2 columns (number,country), got %d", i+1, len(row))
+		}
+		data := NumberData{Number: row[0], Country: row[1]}
+		if len(row) > 2 {
+			data.Callback = row[2]
 		}
+		numbers = append(numbers, data)
 	}
-	
-	return len(cleanNumber) >= 8 && len(cleanNumber) <= 15
+
+	return numbers, nil
 }
 
-func main() {
-	apiKey := os.Getenv("WHATSAPP_RT_API_KEY")
-	if apiKey == "" {
-		apiKey = "YOUR_API_KEY"
+// readNumbersJSONL reads one NumberData JSON object per line, as consumed by
+// the CLI's --input/--format jsonl flags.
+func readNumbersJSONL(path string) ([]NumberData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open input: %v", err)
 	}
+	defer f.Close()
 
-	checker := NewWhatsAppRealtimeChecker(apiKey)
+	var numbers []NumberData
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var data NumberData
+		if err := json.Unmarshal([]byte(line), &data); err != nil {
+			return nil, fmt.Errorf("failed to decode jsonl line: %v", err)
+		}
+		numbers = append(numbers, data)
+	}
+
+	return numbers, scanner.Err()
+}
 
-	// Single number check
-	fmt.Println("=== Single Number Check ===")
-	result, err := checker.CheckNumber("628138800001", "ID", "")
+// readResultsJSONL reads one CheckResult JSON object per line, as produced
+// by writeResults and consumed by the CLI's "stats" subcommand.
+func readResultsJSONL(path string) ([]CheckResult, error) {
+	f, err := os.Open(path)
 	if err != nil {
-		log.Printf("Error: %v", err)
-	} else {
-		fmt.Println("Result:", checker.FormatResult(result))
-		resultJSON, _ := json.MarshalIndent(result, "", "  ")
-		fmt.Println("Raw Response:", string(resultJSON))
-	}
-
-	fmt.Println("\n=== Multiple Numbers Check ===")
-	// Multiple numbers check
-	numbersToCheck := []NumberData{
-		{Number: "628138800001", Country: "ID"},
-		{Number: "5511999999999", Country: "BR"},
-		{Number: "5215555555555", Country: "MX"},
-		{Number: "919876543210", Country: "IN"},
-	}
-
-	results := checker.CheckMultipleNumbers(numbersToCheck, 1000)
-
-	fmt.Println("\n=== Results Summary ===")
-	for i, result := range results {
-		inputData := result.Input
-		if result.Success && result.Result != nil {
-			formatted := checker.FormatResult(result.Result)
-			fmt.Printf("%d. %s\n", i+1, formatted)
-		} else {
-			fmt.Printf("%d. Error for %s (%s): %s\n", 
-				i+1, inputData.Number, inputData.Country, result.ErrorMessage)
+		return nil, fmt.Errorf("failed to open results file: %v", err)
+	}
+	defer f.Close()
+
+	var results []CheckResult
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
 		}
+		var result CheckResult
+		if err := json.Unmarshal([]byte(line), &result); err != nil {
+			return nil, fmt.Errorf("failed to decode jsonl line: %v", err)
+		}
+		results = append(results, result)
+	}
+
+	return results, scanner.Err()
+}
+
+// writeResults writes results to path in the given format ("csv", "jsonl",
+// or "report" for a human-readable Prometheus-style text summary).
+func writeResults(path, format string, results []CheckResult) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create output: %v", err)
 	}
+	defer f.Close()
 
-	// Statistics
-	stats := checker.GetStatistics(results)
-	fmt.Println("\n=== Statistics ===")
-	fmt.Printf("Total Checks: %d\n", stats.Total)
-	fmt.Printf("Successful: %d\n", stats.Successful)
-	fmt.Printf("Failed: %d\n", stats.Failed)
-	fmt.Printf("WhatsApp Yes: %d\n", stats.WhatsAppYes)
-	fmt.Printf("WhatsApp No: %d\n", stats.WhatsAppNo)
+	switch format {
+	case "csv":
+		writer := csv.NewWriter(f)
+		for _, result := range results {
+			whatsapp := ""
+			if result.Result != nil && result.Result.Message != nil {
+				whatsapp = result.Result.Message.WhatsApp
+			}
+			if err := writer.Write([]string{
+				result.Input.Number, result.Input.Country,
+				fmt.Sprintf("%t", result.Success), whatsapp, result.ErrorMessage,
+			}); err != nil {
+				return fmt.Errorf("failed to write csv row: %v", err)
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+
+	case "jsonl":
+		for _, result := range results {
+			line, err := json.Marshal(result)
+			if err != nil {
+				return fmt.Errorf("failed to encode result: %v", err)
+			}
+			if _, err := f.Write(append(line, '\n')); err != nil {
+				return fmt.Errorf("failed to write result: %v", err)
+			}
+		}
+		return nil
+
+	case "report":
+		stats := (&WhatsAppRealtimeChecker{}).GetStatistics(results)
+		fmt.Fprintf(f, "whatsapp_checks_total %d\n", stats.Total)
+		fmt.Fprintf(f, "whatsapp_checks_successful %d\n", stats.Successful)
+		fmt.Fprintf(f, "whatsapp_checks_failed %d\n", stats.Failed)
+		fmt.Fprintf(f, "whatsapp_numbers_active %d\n", stats.WhatsAppYes)
+		fmt.Fprintf(f, "whatsapp_numbers_inactive %d\n", stats.WhatsAppNo)
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:   "whatsapp-checker",
+		Short: "Bulk-verify phone numbers against WhatsApp via the checknumber.ai realtime API",
+	}
+
+	root.AddCommand(newCheckCmd(), newBatchCmd(), newStatsCmd())
+	return root
+}
+
+func newCheckCmd() *cobra.Command {
+	var number, country string
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check a single number",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			checker := NewWhatsAppRealtimeChecker(apiKeyFromEnv())
+			result, err := checker.CheckNumber(number, country, "")
+			if err != nil {
+				return err
+			}
+			fmt.Println(checker.FormatResult(result))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&number, "number", "", "phone number to check")
+	cmd.Flags().StringVar(&country, "country", "", "ISO country code, e.g. BR")
+	cmd.MarkFlagRequired("number")
+	cmd.MarkFlagRequired("country")
+
+	return cmd
+}
+
+func newBatchCmd() *cobra.Command {
+	var input, inputFormat, output, outputFormat string
+	var workers int
+	var rps float64
+
+	cmd := &cobra.Command{
+		Use:   "batch",
+		Short: "Check numbers from a CSV or JSONL file and write results",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var numbers []NumberData
+			var err error
+
+			switch inputFormat {
+			case "csv":
+				numbers, err = readNumbersCSV(input)
+			case "jsonl":
+				numbers, err = readNumbersJSONL(input)
+			default:
+				return fmt.Errorf("unsupported input format: %s", inputFormat)
+			}
+			if err != nil {
+				return err
+			}
+
+			checker := NewWhatsAppRealtimeChecker(apiKeyFromEnv())
+			resultsCh := checker.CheckMultipleNumbersConcurrent(cmd.Context(), numbers, ConcurrentOptions{
+				Workers: workers,
+				RPS:     rps,
+			})
+
+			results := make([]CheckResult, 0, len(numbers))
+			for result := range resultsCh {
+				results = append(results, result)
+			}
+
+			return writeResults(output, outputFormat, results)
+		},
+	}
+
+	cmd.Flags().StringVar(&input, "input", "", "path to input numbers file")
+	cmd.Flags().StringVar(&inputFormat, "format", "csv", "input format: csv or jsonl")
+	cmd.Flags().StringVar(&output, "output", "results.jsonl", "path to write results")
+	cmd.Flags().StringVar(&outputFormat, "output-format", "jsonl", "output format: csv, jsonl, or report")
+	cmd.Flags().IntVar(&workers, "workers", 10, "number of numbers checked concurrently")
+	cmd.Flags().Float64Var(&rps, "rps", 5, "maximum aggregate requests per second")
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+func newStatsCmd() *cobra.Command {
+	var input string
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Aggregate an existing results file into summary statistics",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			results, err := readResultsJSONL(input)
+			if err != nil {
+				return err
+			}
+
+			checker := &WhatsAppRealtimeChecker{}
+			stats := checker.GetStatistics(results)
+			fmt.Printf("Total: %d  Successful: %d  Failed: %d  WhatsApp Yes: %d  WhatsApp No: %d\n",
+				stats.Total, stats.Successful, stats.Failed, stats.WhatsAppYes, stats.WhatsAppNo)
+
+			fmt.Println("\nBy country:")
+			for _, b := range checker.GetStatisticsByCountry(results) {
+				fmt.Printf("  %s: total=%d successful=%d failed=%d yes=%d no=%d\n",
+					b.Country, b.Total, b.Successful, b.Failed, b.WhatsAppYes, b.WhatsAppNo)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&input, "input", "", "path to a results.jsonl file")
+	cmd.MarkFlagRequired("input")
+
+	return cmd
+}
+
+func apiKeyFromEnv() string {
+	apiKey := os.Getenv("WHATSAPP_RT_API_KEY")
+	if apiKey == "" {
+		apiKey = "YOUR_API_KEY"
+	}
+	return apiKey
+}
+
+func main() {
+	if err := newRootCmd().Execute(); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestVerifyCallbackSignature(t *testing.T) {
+	secret := "whsec_test"
+	body := []byte(`{"jobId":"job-1","result":{}}`)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	valid := hex.EncodeToString(mac.Sum(nil))
+
+	cases := []struct {
+		name      string
+		signature string
+		want      bool
+	}{
+		{"valid signature", valid, true},
+		{"wrong secret", "deadbeef", false},
+		{"empty signature", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := verifyCallbackSignature(secret, body, tc.signature); got != tc.want {
+				t.Errorf("verifyCallbackSignature() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestMemoryCacheLRUEviction(t *testing.T) {
+	cache := NewMemoryCache(2)
+
+	cache.Put("+1", &WhatsAppResponse{Status: "OK"}, time.Hour)
+	cache.Put("+2", &WhatsAppResponse{Status: "OK"}, time.Hour)
+
+	// Touch "+1" so "+2" becomes the least recently used entry.
+	if _, ok := cache.Get("+1"); !ok {
+		t.Fatalf("expected +1 to be cached")
+	}
+
+	cache.Put("+3", &WhatsAppResponse{Status: "OK"}, time.Hour)
+
+	if _, ok := cache.Get("+2"); ok {
+		t.Errorf("expected +2 to be evicted as least recently used")
+	}
+	if _, ok := cache.Get("+1"); !ok {
+		t.Errorf("expected +1 to still be cached")
+	}
+	if _, ok := cache.Get("+3"); !ok {
+		t.Errorf("expected +3 to be cached")
+	}
+}
+
+func TestMemoryCacheExpiry(t *testing.T) {
+	cache := NewMemoryCache(10)
+
+	cache.Put("+1", &WhatsAppResponse{Status: "OK"}, -time.Second)
+
+	if _, ok := cache.Get("+1"); ok {
+		t.Errorf("expected expired entry to be evicted on read")
+	}
+}
+
+func TestBackoffDelayBounds(t *testing.T) {
+	for attempt := 0; attempt < 10; attempt++ {
+		delay := backoffDelay(attempt)
+
+		maxWithJitter := time.Duration(float64(backoffCap) * (1 + backoffJitter))
+		if delay < 0 || delay > maxWithJitter {
+			t.Errorf("attempt %d: backoffDelay() = %v, want within [0, %v]", attempt, delay, maxWithJitter)
+		}
+	}
+}
+
+func TestErrorKind(t *testing.T) {
+	cases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{"parse error", &NumberParseError{Kind: ErrNotMobile, Err: errors.New("not mobile")}, "invalid_number"},
+		{"http error", fmt.Errorf("HTTP error %d: %s", 503, "unavailable"), "http"},
+		{"decode error", fmt.Errorf("failed to decode response: %v", errors.New("bad json")), "decode"},
+		{"request build error", fmt.Errorf("failed to create request: %v", errors.New("bad url")), "request"},
+		{"other error", errors.New("connection reset"), "network"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := errorKind(tc.err); got != tc.want {
+				t.Errorf("errorKind() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}